@@ -0,0 +1,113 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+)
+
+// Configuration is the data handed to every template, decoded from -config
+// and then merged with the -package flag and any -source Types.
+type Configuration struct {
+	Package  string `yaml:"Package"`
+	Count    int    `yaml:"Count"`
+	Material string `yaml:"Material"`
+
+	// Zero, Decl and LessBody are free-form snippets a template can splice
+	// in verbatim, e.g. a zero value, a type declaration and the body of a
+	// Less function for a generated set/sort type.
+	Zero     string `yaml:"Zero"`
+	Decl     string `yaml:"Decl"`
+	LessBody string `yaml:"LessBody"`
+
+	// Extra holds any additional user-defined keys from -config that don't
+	// map to one of the named fields above, for templates with their own
+	// bespoke configuration shape.
+	Extra map[string]interface{} `json:"-"`
+
+	// Types holds the exported named types found under -source (filtered by
+	// -type, if set), for templates that generate code from real Go types
+	// rather than hand-edited config.
+	Types []TypeInfo `json:"-"`
+}
+
+// loadConfig reads and decodes the configuration at path, dispatching on its
+// file extension (.json, .toml, .yaml/.yml). It returns the named fields
+// plus Extra for any keys loadConfig doesn't know about.
+func loadConfig(path string) (Configuration, error) {
+	rawBytes, err := os.ReadFile(path)
+	if err != nil {
+		return Configuration{}, err
+	}
+
+	var config Configuration
+	var extra map[string]interface{}
+
+	switch ext := strings.ToLower(filepath.Ext(path)); ext {
+	case ".json":
+		if err := json.Unmarshal(rawBytes, &config); err != nil {
+			return Configuration{}, fmt.Errorf("decoding %s as JSON: %w", path, err)
+		}
+		err = json.Unmarshal(rawBytes, &extra)
+	case ".toml":
+		if err := toml.Unmarshal(rawBytes, &config); err != nil {
+			return Configuration{}, fmt.Errorf("decoding %s as TOML: %w", path, err)
+		}
+		err = toml.Unmarshal(rawBytes, &extra)
+	case ".yaml", ".yml":
+		if err := yaml.Unmarshal(rawBytes, &config); err != nil {
+			return Configuration{}, fmt.Errorf("decoding %s as YAML: %w", path, err)
+		}
+		err = yaml.Unmarshal(rawBytes, &extra)
+	default:
+		return Configuration{}, fmt.Errorf("unrecognized config extension %q (want .json, .toml or .yaml)", ext)
+	}
+	if err != nil {
+		return Configuration{}, fmt.Errorf("decoding %s: %w", path, err)
+	}
+
+	for key := range extra {
+		if knownConfigFields[strings.ToLower(key)] {
+			delete(extra, key)
+		}
+	}
+	if len(extra) > 0 {
+		config.Extra = extra
+	}
+	return config, nil
+}
+
+// knownConfigFields is the set of Configuration's field names, lower-cased,
+// computed once via reflection so it can't drift out of sync with the
+// struct the way a hand-maintained string list did.
+var knownConfigFields = func() map[string]bool {
+	known := make(map[string]bool)
+	t := reflect.TypeOf(Configuration{})
+	for i := 0; i < t.NumField(); i++ {
+		known[strings.ToLower(t.Field(i).Name)] = true
+	}
+	return known
+}()
+
+// validateConfig checks that the fields every template can rely on are
+// present, returning a single error listing everything missing rather than
+// failing deep inside template execution.
+func validateConfig(config Configuration) error {
+	var problems []string
+	if config.Package == "" {
+		problems = append(problems, "Package must not be empty")
+	}
+	if config.Count <= 0 {
+		problems = append(problems, "Count must be a positive integer")
+	}
+	if len(problems) > 0 {
+		return fmt.Errorf("invalid configuration: %s", strings.Join(problems, "; "))
+	}
+	return nil
+}
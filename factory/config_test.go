@@ -0,0 +1,90 @@
+package main
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func writeTempConfig(t *testing.T, name, contents string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := writeDeterministic(path, []byte(contents)); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoadConfigJSON(t *testing.T) {
+	path := writeTempConfig(t, "config.json", `{"Package":"widgets","Count":3,"Material":"wood","Foo":"bar"}`)
+
+	got, err := loadConfig(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := Configuration{Package: "widgets", Count: 3, Material: "wood", Extra: map[string]interface{}{"Foo": "bar"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+}
+
+func TestLoadConfigTOML(t *testing.T) {
+	path := writeTempConfig(t, "config.toml", "Package = \"widgets\"\nCount = 3\nMaterial = \"wood\"\nFoo = \"bar\"\n")
+
+	got, err := loadConfig(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Package != "widgets" || got.Count != 3 || got.Material != "wood" {
+		t.Errorf("got %+v, want named fields widgets/3/wood", got)
+	}
+	if got.Extra["Foo"] != "bar" {
+		t.Errorf("got Extra %+v, want Foo=bar", got.Extra)
+	}
+}
+
+// TestLoadConfigYAML guards against the yaml.v3 default of matching keys
+// case-sensitively against the *lowercased* field name: without explicit
+// yaml tags, the same capitalized keys used throughout this series' JSON
+// and TOML examples would silently decode to the zero value.
+func TestLoadConfigYAML(t *testing.T) {
+	path := writeTempConfig(t, "config.yaml", "Package: widgets\nCount: 3\nMaterial: wood\nFoo: bar\n")
+
+	got, err := loadConfig(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := Configuration{Package: "widgets", Count: 3, Material: "wood", Extra: map[string]interface{}{"Foo": "bar"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+}
+
+func TestLoadConfigUnrecognizedExtension(t *testing.T) {
+	path := writeTempConfig(t, "config.ini", "Package=widgets\n")
+
+	if _, err := loadConfig(path); err == nil {
+		t.Error("expected an error for an unrecognized config extension")
+	}
+}
+
+func TestValidateConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  Configuration
+		wantErr bool
+	}{
+		{"valid", Configuration{Package: "widgets", Count: 1}, false},
+		{"empty package", Configuration{Package: "", Count: 1}, true},
+		{"zero count", Configuration{Package: "widgets", Count: 0}, true},
+		{"negative count", Configuration{Package: "widgets", Count: -1}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateConfig(tt.config)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("validateConfig(%+v) error = %v, wantErr %v", tt.config, err, tt.wantErr)
+			}
+		})
+	}
+}
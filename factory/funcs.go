@@ -0,0 +1,136 @@
+package main
+
+import (
+	"fmt"
+	"os/exec"
+	"strconv"
+	"strings"
+	"sync"
+	"text/template"
+	"unicode"
+)
+
+// funcMap returns the functions available to every template rendered by
+// this tool: case conversions for turning config/type names into idiomatic
+// Go identifiers, plus quote/plural/typeOf/getCommit helpers.
+func funcMap() template.FuncMap {
+	return template.FuncMap{
+		"title":     title,
+		"camelCase": camelCase,
+		"snakeCase": snakeCase,
+		"quote":     strconv.Quote,
+		"plural":    plural,
+		"typeOf":    func(v interface{}) string { return fmt.Sprintf("%T", v) },
+		"getCommit": getCommit,
+	}
+}
+
+// title upper-cases the first letter of each word, where words are
+// separated by spaces, underscores or hyphens, e.g. "some_field" -> "Some Field".
+func title(s string) string {
+	return titleCase(splitWords(s), " ")
+}
+
+// camelCase joins words with the first word lower-cased and every
+// subsequent word title-cased, e.g. "some_field" -> "someField".
+func camelCase(s string) string {
+	words := splitWords(s)
+	if len(words) == 0 {
+		return ""
+	}
+	var b strings.Builder
+	b.WriteString(strings.ToLower(words[0]))
+	b.WriteString(titleCase(words[1:], ""))
+	return b.String()
+}
+
+// snakeCase lower-cases s and joins its words with underscores, e.g.
+// "SomeField" -> "some_field".
+func snakeCase(s string) string {
+	words := splitWords(s)
+	for i, w := range words {
+		words[i] = strings.ToLower(w)
+	}
+	return strings.Join(words, "_")
+}
+
+func titleCase(words []string, sep string) string {
+	for i, w := range words {
+		if w == "" {
+			continue
+		}
+		r := []rune(w)
+		r[0] = unicode.ToUpper(r[0])
+		words[i] = string(r)
+	}
+	return strings.Join(words, sep)
+}
+
+// splitWords breaks s into words on space/underscore/hyphen boundaries and
+// on lower-to-upper case transitions, so it handles "some_field",
+// "some-field" and "SomeField" alike.
+func splitWords(s string) []string {
+	var words []string
+	var cur strings.Builder
+	flush := func() {
+		if cur.Len() > 0 {
+			words = append(words, cur.String())
+			cur.Reset()
+		}
+	}
+	runes := []rune(s)
+	for i, r := range runes {
+		switch {
+		case r == ' ' || r == '_' || r == '-':
+			flush()
+		case unicode.IsUpper(r) && i > 0 && !unicode.IsUpper(runes[i-1]):
+			flush()
+			cur.WriteRune(r)
+		default:
+			cur.WriteRune(r)
+		}
+	}
+	flush()
+	return words
+}
+
+// plural applies a few common English pluralization rules; it is meant for
+// generated variable/field names, not natural-language text.
+func plural(s string) string {
+	switch {
+	case strings.HasSuffix(s, "y") && len(s) > 1 && !isVowel(rune(s[len(s)-2])):
+		return s[:len(s)-1] + "ies"
+	case strings.HasSuffix(s, "s"), strings.HasSuffix(s, "x"),
+		strings.HasSuffix(s, "ch"), strings.HasSuffix(s, "sh"):
+		return s + "es"
+	default:
+		return s + "s"
+	}
+}
+
+func isVowel(r rune) bool {
+	switch unicode.ToLower(r) {
+	case 'a', 'e', 'i', 'o', 'u':
+		return true
+	}
+	return false
+}
+
+var (
+	commitOnce  sync.Once
+	commitValue string
+)
+
+// getCommit returns the short VCS revision of the working directory, or
+// "unknown" if it can't be determined (e.g. not a git checkout).
+func getCommit() string {
+	commitOnce.Do(func() {
+		out, err := exec.Command("git", "rev-parse", "--short", "HEAD").Output()
+		if err != nil {
+			commitValue = "unknown"
+			return
+		}
+		commitValue = strings.TrimSpace(string(out))
+	})
+	return commitValue
+}
@@ -0,0 +1,69 @@
+package main
+
+import "testing"
+
+func TestTitle(t *testing.T) {
+	tests := map[string]string{
+		"some_field":  "Some Field",
+		"some-field":  "Some Field",
+		"SomeField":   "Some Field",
+		"alreadyDone": "Already Done",
+		"simple":      "Simple",
+	}
+	for in, want := range tests {
+		if got := title(in); got != want {
+			t.Errorf("title(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestCamelCase(t *testing.T) {
+	tests := map[string]string{
+		"some_field": "someField",
+		"some-field": "someField",
+		"SomeField":  "someField",
+		"simple":     "simple",
+	}
+	for in, want := range tests {
+		if got := camelCase(in); got != want {
+			t.Errorf("camelCase(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestSnakeCase(t *testing.T) {
+	tests := map[string]string{
+		"SomeField":  "some_field",
+		"some-field": "some_field",
+		"someField":  "some_field",
+		"simple":     "simple",
+	}
+	for in, want := range tests {
+		if got := snakeCase(in); got != want {
+			t.Errorf("snakeCase(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestPlural(t *testing.T) {
+	tests := map[string]string{
+		"thing": "things",
+		"box":   "boxes",
+		"bus":   "buses",
+		"batch": "batches",
+		"dish":  "dishes",
+		"city":  "cities",
+		"key":   "keys",
+	}
+	for in, want := range tests {
+		if got := plural(in); got != want {
+			t.Errorf("plural(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestGetCommit(t *testing.T) {
+	if got := getCommit(); got == "" {
+		t.Error("getCommit() returned an empty string, want a revision or \"unknown\"")
+	}
+}
@@ -0,0 +1,7 @@
+// Package gen_fixture is a minimal fixture exercising -generate mode end to
+// end: thing_gen.go below is produced from thing.tmpl and config.json by the
+// //go:generate directive here, and is checked in so the package builds
+// without requiring a generate step first.
+package gen_fixture
+
+//go:generate go run github.com/linzhp/codegen_example/factory -generate -tmpl thing.tmpl -config config.json -out thing_gen.go
@@ -0,0 +1,15 @@
+package gen_fixture
+
+// Thing is a steel thing.
+type Thing struct {
+	Material string
+}
+
+// NewThings returns 2 new Things.
+func NewThings() []Thing {
+	things := make([]Thing, 2)
+	for i := range things {
+		things[i] = Thing{Material: "steel"}
+	}
+	return things
+}
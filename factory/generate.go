@@ -0,0 +1,61 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// generateInputs holds the source directory, output directory, package name
+// and invocation line derived from either `go generate` or Bazel-style
+// driver inputs.
+type generateInputs struct {
+	SourceDir string
+	OutDir    string
+	Package   string
+	// Line is the 1-based line number of the //go:generate directive that
+	// invoked this process (from GOLINE), or 0 in Bazel mode. It lets
+	// multiple //go:generate directives in one GOFILE, which would
+	// otherwise all default to the same -out, produce distinct files.
+	Line int
+}
+
+// resolveGenerateInputs determines -generate mode's inputs. It prefers the
+// environment variables `go generate` sets (GOFILE, GOPACKAGE, GOLINE);
+// if those are unset it falls back to the Bazel-style -srcs/-outdir flags,
+// so the same binary can run as a go:generate directive or as a genrule
+// action. ok is false if neither source of inputs is available.
+func resolveGenerateInputs(srcsFlag, outdirFlag string) (inputs generateInputs, ok bool) {
+	if goFile, goPackage := os.Getenv("GOFILE"), os.Getenv("GOPACKAGE"); goFile != "" && goPackage != "" {
+		dir := filepath.Dir(goFile)
+		line, _ := strconv.Atoi(os.Getenv("GOLINE"))
+		return generateInputs{SourceDir: dir, OutDir: dir, Package: goPackage, Line: line}, true
+	}
+
+	if srcsFlag != "" {
+		srcs := strings.Split(srcsFlag, ",")
+		sort.Strings(srcs)
+		return generateInputs{SourceDir: filepath.Dir(srcs[0]), OutDir: outdirFlag}, true
+	}
+
+	return generateInputs{}, false
+}
+
+// writeDeterministic writes contents to path with LF line endings, so the
+// output of -generate mode is byte-identical across runs and across OSes,
+// as required when wiring this tool into a Bazel genrule action.
+func writeDeterministic(path string, contents []byte) error {
+	normalized := bytes.ReplaceAll(contents, []byte("\r\n"), []byte("\n"))
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, normalized, 0o644)
+}
+
+func (inputs generateInputs) String() string {
+	return fmt.Sprintf("source=%s out=%s package=%s line=%d", inputs.SourceDir, inputs.OutDir, inputs.Package, inputs.Line)
+}
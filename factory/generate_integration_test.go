@@ -0,0 +1,44 @@
+package main
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+)
+
+// TestGenerateIsDeterministic runs `go generate ./...` against gen_fixture
+// twice and asserts the resulting thing_gen.go is byte-identical both times,
+// as required of -generate mode's output.
+func TestGenerateIsDeterministic(t *testing.T) {
+	if _, err := exec.LookPath("go"); err != nil {
+		t.Skip("go toolchain not available")
+	}
+
+	root, err := filepath.Abs("..")
+	if err != nil {
+		t.Fatal(err)
+	}
+	outPath := filepath.Join(root, "factory", "gen_fixture", "thing_gen.go")
+
+	runGenerate := func() []byte {
+		t.Helper()
+		cmd := exec.Command("go", "generate", "./...")
+		cmd.Dir = root
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("go generate ./...: %v\n%s", err, out)
+		}
+		contents, err := os.ReadFile(outPath)
+		if err != nil {
+			t.Fatalf("reading generated file: %v", err)
+		}
+		return contents
+	}
+
+	first := runGenerate()
+	second := runGenerate()
+
+	if string(first) != string(second) {
+		t.Fatalf("generated output is not byte-identical across runs:\n--- first ---\n%s\n--- second ---\n%s", first, second)
+	}
+}
@@ -0,0 +1,61 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestResolveGenerateInputsFromEnv(t *testing.T) {
+	t.Setenv("GOFILE", "doc.go")
+	t.Setenv("GOPACKAGE", "widgets")
+	t.Setenv("GOLINE", "7")
+
+	inputs, ok := resolveGenerateInputs("", "")
+	if !ok {
+		t.Fatal("expected ok=true when GOFILE/GOPACKAGE are set")
+	}
+	want := generateInputs{SourceDir: ".", OutDir: ".", Package: "widgets", Line: 7}
+	if inputs != want {
+		t.Errorf("got %+v, want %+v", inputs, want)
+	}
+}
+
+func TestResolveGenerateInputsFromBazelFlags(t *testing.T) {
+	t.Setenv("GOFILE", "")
+	t.Setenv("GOPACKAGE", "")
+
+	inputs, ok := resolveGenerateInputs("b.go,a.go", "out")
+	if !ok {
+		t.Fatal("expected ok=true when -srcs is set")
+	}
+	want := generateInputs{SourceDir: ".", OutDir: "out"}
+	if inputs != want {
+		t.Errorf("got %+v, want %+v", inputs, want)
+	}
+}
+
+func TestResolveGenerateInputsNone(t *testing.T) {
+	t.Setenv("GOFILE", "")
+	t.Setenv("GOPACKAGE", "")
+
+	if _, ok := resolveGenerateInputs("", ""); ok {
+		t.Error("expected ok=false with no env vars and no -srcs")
+	}
+}
+
+func TestWriteDeterministicNormalizesLineEndings(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "out.go")
+	if err := writeDeterministic(path, []byte("package p\r\n\r\nfunc f() {}\r\n")); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "package p\n\nfunc f() {}\n"
+	if string(got) != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,40 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"text/template"
+)
+
+// Provenance describes where a generated file came from, for the
+// "DO NOT EDIT" banner rendered from header.tmpl/footer.tmpl.
+type Provenance struct {
+	Generator string // the program name, e.g. "factory"
+	Config    string // the -config path used to render this file
+	Commit    string // the VCS revision of the working directory, via getCommit
+}
+
+// renderBanner renders the file called name (header.tmpl or footer.tmpl) in
+// tmplDir (if one exists) against provenance, and returns its bytes. It returns nil
+// with no error if no such file exists, so templates that don't want a
+// banner aren't forced to have one.
+func renderBanner(tmplDir, name string, provenance Provenance, funcs template.FuncMap) ([]byte, error) {
+	path := filepath.Join(tmplDir, name)
+	rawBytes, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	tmpl, err := template.New(name).Funcs(funcs).Parse(string(rawBytes))
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, provenance); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,35 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestRenderBannerMissingFileReturnsNil(t *testing.T) {
+	got, err := renderBanner(t.TempDir(), "header.tmpl", Provenance{}, funcMap())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != nil {
+		t.Errorf("got %q, want nil for a missing header.tmpl", got)
+	}
+}
+
+func TestRenderBanner(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "header.tmpl")
+	if err := os.WriteFile(path, []byte("// Code generated by {{.Generator}} from {{.Config}}; DO NOT EDIT.\n// commit: {{.Commit}}\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	provenance := Provenance{Generator: "factory", Config: "base.json", Commit: "abc1234"}
+	got, err := renderBanner(dir, "header.tmpl", provenance, funcMap())
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "// Code generated by factory from base.json; DO NOT EDIT.\n// commit: abc1234\n"
+	if string(got) != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
@@ -1,43 +1,78 @@
 package main
 
 import (
-	"encoding/json"
 	"flag"
-	"html/template"
-	"io/ioutil"
+	"fmt"
 	"log"
 	"os"
+	"path/filepath"
+	"strings"
 )
 
 func main() {
 	pkg := flag.String("package", "codegen", "the package name in the generated code file")
-	tmplPath := flag.String("tmpl", "factory/templates/things.tmpl", "the template file")
-	configPath := flag.String("config", "factory/config/base.json", "the configuration file")
-	outPath := flag.String("out", "out.go", "the output file")
+	tmplPath := flag.String("tmpl", "factory/templates/things.tmpl", "the template file, a comma-separated list, or a directory of *.tmpl files")
+	configPath := flag.String("config", "factory/config/base.json", "the configuration file (.json, .toml or .yaml)")
+	outPath := flag.String("out", "out.go", "the output file, a comma-separated list matching -tmpl, or an output directory")
+	sourcePath := flag.String("source", "", "a Go package directory to introspect for Types, instead of only the -config file")
+	typeFilter := flag.String("type", "", "a filepath.Match pattern restricting which exported types from -source are exposed as Types")
+	generate := flag.Bool("generate", false, "driver mode: source -out from the go:generate environment (GOFILE, GOPACKAGE) or, if unset, from -srcs/-outdir")
+	srcs := flag.String("srcs", "", "Bazel-style comma-separated source files, used by -generate when GOFILE/GOPACKAGE are unset")
+	outdir := flag.String("outdir", "", "Bazel-style output directory, used by -generate when GOFILE/GOPACKAGE are unset")
+	sourceFromGenerate := flag.Bool("source-from-generate", false, "with -generate and no explicit -source, introspect the go:generate/Bazel target directory for Types; off by default since that directory often doesn't compile until this tool runs")
 	flag.Parse()
-	file, err := os.Open(*configPath)
-	check(err)
-	decoder := json.NewDecoder(file)
-	var config Configuration
-	if err = decoder.Decode(&config); err != nil {
-		log.Fatal(err)
+
+	explicitOut := false
+	flag.Visit(func(f *flag.Flag) {
+		if f.Name == "out" {
+			explicitOut = true
+		}
+	})
+
+	if *generate {
+		inputs, ok := resolveGenerateInputs(*srcs, *outdir)
+		if !ok {
+			log.Fatal("-generate requires either GOFILE/GOPACKAGE in the environment or -srcs/-outdir")
+		}
+		if *sourcePath == "" && *sourceFromGenerate {
+			*sourcePath = inputs.SourceDir
+		}
+		if inputs.Package != "" {
+			*pkg = inputs.Package
+		}
+		// A bare GOLINE-less -out would collide across multiple
+		// //go:generate directives in the same GOFILE, so disambiguate the
+		// default with the invoking line number.
+		if !explicitOut && inputs.Line > 0 {
+			ext := filepath.Ext(*outPath)
+			*outPath = fmt.Sprintf("%s_%d%s", strings.TrimSuffix(*outPath, ext), inputs.Line, ext)
+		}
+		if inputs.OutDir != "" {
+			*outPath = filepath.Join(inputs.OutDir, *outPath)
+		}
 	}
-	config.Package = *pkg
 
-	rawBytes, err := ioutil.ReadFile(*tmplPath)
-	check(err)
-	tmpl, err := template.New("thing").Parse(string(rawBytes))
-	check(err)
-	out, err := os.Create(*outPath)
-	check(err)
-	err = tmpl.Execute(out, config)
+	config, err := loadConfig(*configPath)
 	check(err)
-}
+	config.Package = *pkg
+	check(validateConfig(config))
+
+	if *sourcePath != "" {
+		config.Types, err = loadTypes(*sourcePath, *typeFilter)
+		check(err)
+	}
 
-type Configuration struct {
-	Package  string
-	Count    int
-	Material string
+	provenance := Provenance{
+		Generator: filepath.Base(os.Args[0]),
+		Config:    *configPath,
+		Commit:    getCommit(),
+	}
+
+	tmplPaths, outPaths, err := resolveTemplates(*tmplPath, *outPath)
+	check(err)
+	for i, t := range tmplPaths {
+		check(renderTemplate(t, outPaths[i], config, funcMap(), provenance))
+	}
 }
 
 func check(err error) {
@@ -0,0 +1,90 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/template"
+
+	"golang.org/x/tools/imports"
+)
+
+// resolveTemplates expands the -tmpl/-out flags into parallel lists of
+// template and output paths. tmplFlag/outFlag may each be a single path, a
+// comma-separated list of paths, or (when tmplFlag names a directory) a
+// directory of *.tmpl files paired with outFlag as the output directory.
+func resolveTemplates(tmplFlag, outFlag string) (tmplPaths, outPaths []string, err error) {
+	if info, statErr := os.Stat(tmplFlag); statErr == nil && info.IsDir() {
+		matches, globErr := filepath.Glob(filepath.Join(tmplFlag, "*.tmpl"))
+		if globErr != nil {
+			return nil, nil, globErr
+		}
+		sort.Strings(matches)
+		for _, tmplPath := range matches {
+			base := strings.TrimSuffix(filepath.Base(tmplPath), ".tmpl")
+			tmplPaths = append(tmplPaths, tmplPath)
+			outPaths = append(outPaths, filepath.Join(outFlag, base+".go"))
+		}
+		return tmplPaths, outPaths, nil
+	}
+
+	tmplPaths = strings.Split(tmplFlag, ",")
+	outPaths = strings.Split(outFlag, ",")
+	if len(tmplPaths) != len(outPaths) {
+		return nil, nil, fmt.Errorf("-tmpl lists %d template(s) but -out lists %d output(s)", len(tmplPaths), len(outPaths))
+	}
+	return tmplPaths, outPaths, nil
+}
+
+// renderTemplate parses tmplPath, executes it against config, formats the
+// result with go/format and golang.org/x/tools/imports, and writes it to
+// outPath. Rendering through gofmt/goimports means a template only needs to
+// produce syntactically valid Go with the right identifiers in scope; it
+// never has to get whitespace or import grouping right by hand.
+//
+// If header.tmpl and/or footer.tmpl exist alongside tmplPath, they are
+// rendered against provenance and wrapped around the body, giving every
+// generated file a "DO NOT EDIT" banner without every .tmpl having to
+// repeat it.
+func renderTemplate(tmplPath, outPath string, config Configuration, funcs template.FuncMap, provenance Provenance) error {
+	rawBytes, err := os.ReadFile(tmplPath)
+	if err != nil {
+		return err
+	}
+	tmpl, err := template.New(filepath.Base(tmplPath)).Funcs(funcs).Parse(string(rawBytes))
+	if err != nil {
+		return fmt.Errorf("parsing %s: %w", tmplPath, err)
+	}
+
+	tmplDir := filepath.Dir(tmplPath)
+	header, err := renderBanner(tmplDir, "header.tmpl", provenance, funcs)
+	if err != nil {
+		return fmt.Errorf("rendering header.tmpl: %w", err)
+	}
+	footer, err := renderBanner(tmplDir, "footer.tmpl", provenance, funcs)
+	if err != nil {
+		return fmt.Errorf("rendering footer.tmpl: %w", err)
+	}
+
+	var buf bytes.Buffer
+	buf.Write(header)
+	if err := tmpl.Execute(&buf, config); err != nil {
+		return fmt.Errorf("executing %s: %w", tmplPath, err)
+	}
+	buf.Write(footer)
+
+	formatted, err := format.Source(buf.Bytes())
+	if err != nil {
+		return fmt.Errorf("gofmt %s: %w", tmplPath, err)
+	}
+	imported, err := imports.Process(outPath, formatted, nil)
+	if err != nil {
+		return fmt.Errorf("goimports %s: %w", tmplPath, err)
+	}
+
+	return writeDeterministic(outPath, imported)
+}
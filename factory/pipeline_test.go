@@ -0,0 +1,114 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestResolveTemplatesCommaLists(t *testing.T) {
+	tmplPaths, outPaths, err := resolveTemplates("a.tmpl,b.tmpl", "a.go,b.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(tmplPaths, []string{"a.tmpl", "b.tmpl"}) {
+		t.Errorf("tmplPaths = %v", tmplPaths)
+	}
+	if !reflect.DeepEqual(outPaths, []string{"a.go", "b.go"}) {
+		t.Errorf("outPaths = %v", outPaths)
+	}
+}
+
+func TestResolveTemplatesMismatchedLists(t *testing.T) {
+	if _, _, err := resolveTemplates("a.tmpl,b.tmpl", "a.go"); err == nil {
+		t.Error("expected an error when -tmpl and -out list lengths differ")
+	}
+}
+
+func TestResolveTemplatesDirectory(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"b.tmpl", "a.tmpl", "ignored.txt"} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte("x"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tmplPaths, outPaths, err := resolveTemplates(dir, "outdir")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{filepath.Join(dir, "a.tmpl"), filepath.Join(dir, "b.tmpl")}
+	if !reflect.DeepEqual(tmplPaths, want) {
+		t.Errorf("tmplPaths = %v, want %v (sorted, *.tmpl only)", tmplPaths, want)
+	}
+	wantOut := []string{filepath.Join("outdir", "a.go"), filepath.Join("outdir", "b.go")}
+	if !reflect.DeepEqual(outPaths, wantOut) {
+		t.Errorf("outPaths = %v, want %v", outPaths, wantOut)
+	}
+}
+
+func TestRenderTemplate(t *testing.T) {
+	dir := t.TempDir()
+	tmplPath := filepath.Join(dir, "thing.tmpl")
+	if err := os.WriteFile(tmplPath, []byte(`package {{.Package}}
+
+func Count() int { return {{.Count}} }
+`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	outPath := filepath.Join(dir, "out.go")
+	config := Configuration{Package: "widgets", Count: 3}
+	if err := renderTemplate(tmplPath, outPath, config, funcMap(), Provenance{}); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := os.ReadFile(outPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "package widgets\n\nfunc Count() int { return 3 }\n"
+	if string(got) != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestRenderTemplateWithHeaderFooter(t *testing.T) {
+	dir := t.TempDir()
+	writeFile := func(name, contents string) {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(contents), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	writeFile("thing.tmpl", "package {{.Package}}\n")
+	writeFile("header.tmpl", "// Code generated by {{.Generator}}; DO NOT EDIT.\n")
+
+	outPath := filepath.Join(dir, "out.go")
+	config := Configuration{Package: "widgets", Count: 1}
+	provenance := Provenance{Generator: "factory"}
+	if err := renderTemplate(filepath.Join(dir, "thing.tmpl"), outPath, config, funcMap(), provenance); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := os.ReadFile(outPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "// Code generated by factory; DO NOT EDIT.\npackage widgets\n"; string(got) != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestRenderTemplateInvalidGoIsAnError(t *testing.T) {
+	dir := t.TempDir()
+	tmplPath := filepath.Join(dir, "bad.tmpl")
+	if err := os.WriteFile(tmplPath, []byte("this is not valid {{.Package}} go"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	err := renderTemplate(tmplPath, filepath.Join(dir, "out.go"), Configuration{Package: "x"}, funcMap(), Provenance{})
+	if err == nil {
+		t.Error("expected an error when the rendered template isn't valid Go")
+	}
+}
@@ -0,0 +1,87 @@
+package main
+
+import (
+	"fmt"
+	"go/token"
+	"go/types"
+	"path/filepath"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// TypeInfo describes an exported named type discovered in a source package,
+// so templates can generate code (factories, registries, ...) from the
+// actual Go types instead of a hand-written config file.
+type TypeInfo struct {
+	Name   string
+	Kind   string // "struct", "interface", or "basic" for any other underlying type
+	Fields []FieldInfo
+}
+
+// FieldInfo describes a single struct field of a TypeInfo.
+type FieldInfo struct {
+	Name string
+	Type string
+	Tag  string
+}
+
+// loadTypes loads the Go package at sourceDir and returns TypeInfo for every
+// exported named type whose name matches filter (a filepath.Match pattern;
+// an empty filter matches everything).
+func loadTypes(sourceDir, filter string) ([]TypeInfo, error) {
+	cfg := &packages.Config{
+		Mode: packages.NeedTypes | packages.NeedName | packages.NeedTypesInfo | packages.NeedDeps,
+		Dir:  sourceDir,
+	}
+	pkgs, err := packages.Load(cfg, ".")
+	if err != nil {
+		return nil, fmt.Errorf("loading package at %s: %w", sourceDir, err)
+	}
+	if packages.PrintErrors(pkgs) > 0 {
+		return nil, fmt.Errorf("package at %s has errors", sourceDir)
+	}
+
+	var infos []TypeInfo
+	for _, pkg := range pkgs {
+		scope := pkg.Types.Scope()
+		for _, name := range scope.Names() {
+			if !token.IsExported(name) {
+				continue
+			}
+			if filter != "" {
+				if ok, err := filepath.Match(filter, name); err != nil {
+					return nil, fmt.Errorf("invalid -type filter %q: %w", filter, err)
+				} else if !ok {
+					continue
+				}
+			}
+			obj, ok := scope.Lookup(name).(*types.TypeName)
+			if !ok {
+				continue
+			}
+			infos = append(infos, typeInfoFor(obj))
+		}
+	}
+	return infos, nil
+}
+
+func typeInfoFor(obj *types.TypeName) TypeInfo {
+	info := TypeInfo{Name: obj.Name()}
+	switch underlying := obj.Type().Underlying().(type) {
+	case *types.Struct:
+		info.Kind = "struct"
+		for i := 0; i < underlying.NumFields(); i++ {
+			field := underlying.Field(i)
+			info.Fields = append(info.Fields, FieldInfo{
+				Name: field.Name(),
+				Type: field.Type().String(),
+				Tag:  underlying.Tag(i),
+			})
+		}
+	case *types.Interface:
+		info.Kind = "interface"
+	default:
+		info.Kind = "basic"
+	}
+	return info
+}
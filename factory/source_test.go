@@ -0,0 +1,70 @@
+package main
+
+import (
+	"sort"
+	"testing"
+)
+
+func TestLoadTypes(t *testing.T) {
+	infos, err := loadTypes("testdata/sourcefixture", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	byName := make(map[string]TypeInfo)
+	var names []string
+	for _, info := range infos {
+		byName[info.Name] = info
+		names = append(names, info.Name)
+	}
+	sort.Strings(names)
+
+	if _, ok := byName["unexportedThing"]; ok {
+		t.Errorf("loadTypes returned unexported type; names = %v", names)
+	}
+
+	widget, ok := byName["Widget"]
+	if !ok {
+		t.Fatalf("loadTypes did not return Widget; names = %v", names)
+	}
+	if widget.Kind != "struct" {
+		t.Errorf("Widget.Kind = %q, want struct", widget.Kind)
+	}
+	wantFields := map[string]FieldInfo{
+		"Name":  {Name: "Name", Type: "string", Tag: `json:"name"`},
+		"Count": {Name: "Count", Type: "int", Tag: `json:"count"`},
+	}
+	if len(widget.Fields) != len(wantFields) {
+		t.Fatalf("Widget.Fields = %+v, want %+v", widget.Fields, wantFields)
+	}
+	for _, field := range widget.Fields {
+		want, ok := wantFields[field.Name]
+		if !ok || field != want {
+			t.Errorf("Widget field %+v, want %+v", field, want)
+		}
+	}
+
+	doer, ok := byName["Doer"]
+	if !ok {
+		t.Fatalf("loadTypes did not return Doer; names = %v", names)
+	}
+	if doer.Kind != "interface" {
+		t.Errorf("Doer.Kind = %q, want interface", doer.Kind)
+	}
+}
+
+func TestLoadTypesFilter(t *testing.T) {
+	infos, err := loadTypes("testdata/sourcefixture", "Sprocket")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(infos) != 1 || infos[0].Name != "Sprocket" {
+		t.Errorf("loadTypes with filter %q = %+v, want only Sprocket", "Sprocket", infos)
+	}
+}
+
+func TestLoadTypesInvalidFilter(t *testing.T) {
+	if _, err := loadTypes("testdata/sourcefixture", "["); err == nil {
+		t.Error("expected an error for an invalid -type filter pattern")
+	}
+}
@@ -0,0 +1,25 @@
+// Package sourcefixture is a fixture package for TestLoadTypes; it has no
+// purpose beyond exercising loadTypes against a few representative type
+// shapes.
+package sourcefixture
+
+// Widget is an exported struct with a couple of tagged fields.
+type Widget struct {
+	Name  string `json:"name"`
+	Count int    `json:"count"`
+}
+
+// Sprocket is another exported struct, used to test -type filtering.
+type Sprocket struct {
+	ID string
+}
+
+// Doer is an exported interface.
+type Doer interface {
+	Do()
+}
+
+// unexportedThing must never show up in loadTypes' results.
+type unexportedThing struct {
+	X int
+}